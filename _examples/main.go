@@ -9,7 +9,7 @@ import (
 	"github.com/reyronald/bindparameters"
 )
 
-func bindChiParametersInto(r *http.Request, fn interface{}) {
+func bindChiParametersInto(w http.ResponseWriter, r *http.Request, fn interface{}) {
 	getURLParam := func(key string) string {
 		if rctx := chi.RouteContext(r.Context()); rctx != nil {
 			for k := len(rctx.URLParams.Keys) - 1; k >= 0; k-- {
@@ -21,7 +21,9 @@ func bindChiParametersInto(r *http.Request, fn interface{}) {
 
 		return ""
 	}
-	bindparameters.Into(r, getURLParam, fn)
+	if _, err := bindparameters.Into(r, getURLParam, fn); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
 }
 
 type user struct {
@@ -35,7 +37,7 @@ func main() {
 
 	// http --ignore-stdin GET :7000/user/1/post/1000
 	router.Get("/user/{id}/post/{postId}", func(w http.ResponseWriter, r *http.Request) {
-		bindChiParametersInto(r, func(params struct {
+		bindChiParametersInto(w, r, func(params struct {
 			ID     int `json:"id"`
 			PostID int `json:"postId"`
 		}) {
@@ -45,7 +47,7 @@ func main() {
 
 	// http --ignore-stdin GET ":7000/query-strings-simple/1?filterInt=25&filterStr=hello&filterBool=true"
 	router.Get("/query-strings-simple/{id}", func(w http.ResponseWriter, r *http.Request) {
-		bindChiParametersInto(r, func(params struct {
+		bindChiParametersInto(w, r, func(params struct {
 			ID         int    `json:"id"`
 			FilterInt  int    `json:"filterInt"`
 			FilterStr  string `json:"filterStr"`
@@ -57,7 +59,7 @@ func main() {
 
 	// http --ignore-stdin GET ":7000/query-strings-arrays/1?filterArrInt[]=1&filterArrInt[]=2&filterArrStr[]=hello"
 	router.Get("/query-strings-arrays/{id}", func(w http.ResponseWriter, r *http.Request) {
-		bindChiParametersInto(r, func(params struct {
+		bindChiParametersInto(w, r, func(params struct {
 			ID            int      `json:"id"`
 			FilterArrInt  []int    `json:"filterArrInt"`
 			FilterArrStr  []string `json:"filterArrStr"`
@@ -69,7 +71,7 @@ func main() {
 
 	// http --ignore-stdin POST :7000/user/1 name=Ronald age=27
 	router.Post("/user/{id}", func(w http.ResponseWriter, r *http.Request) {
-		bindChiParametersInto(r, func(params struct {
+		bindChiParametersInto(w, r, func(params struct {
 			ID int `json:"id"`
 		}, u user) {
 			response := struct {
@@ -4,14 +4,17 @@
 package bindparameters
 
 import (
-	"encoding/json"
+	"bytes"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"strconv"
 	"strings"
 )
 
-// Into will automatically bind or map parameters from the HTTP request `r` into the arguments of `fn`.
+// Into will automatically bind or map parameters from the HTTP request `r` into the arguments of `fn`,
+// and then call `fn` with them.
 // `fn` must be a function with either one or two arguments.
 // The first argument should be a struct with fields that map
 // to URL and query string parameters. The second argument is
@@ -19,144 +22,215 @@ import (
 // If your endpoint doesn't have any URL or query string parameters
 // (or you don't need to access them in your handler), you still need
 // to provide the first argument to the function, but in that case you can pass `nil`.
+// By default a field is looked up by its `json` tag name (if any, else its Go name),
+// first as a URL param, then as a query string parameter if present, the latter
+// overriding the former. A `bind:"path|query|header|cookie,name=..."` tag pins a
+// field to exactly one of those sources and/or renames the key looked up under it.
+// Into returns the return values of `fn`, if any, as []reflect.Value, so callers can call
+// `.Interface()` on them to get back concrete values.
+// If the request cannot be bound into `fn`'s arguments, `fn` is not called and Into
+// returns a non-nil error instead. Use errors.Is/errors.As against ErrInvalidHandler,
+// ErrUnsupportedField, ErrBodyDecode or ErrConversion to distinguish the failure modes.
+// The request body is decoded according to its Content-Type header; application/json,
+// application/xml, application/x-www-form-urlencoded and multipart/form-data are
+// supported out of the box. Pass WithDecoder to override or add to that set.
+// A field tagged `binding:"required"` must be provided by the request, `binding:"ignore"`
+// is never bound even if the client sent it, and `enums:"a,b,c"` rejects values outside
+// the set; these apply to both the params struct and the decoded body. Every violation
+// is collected into a single *ValidationError rather than stopping at the first one.
+// Pass WithValidator to additionally run an external validator such as go-playground/validator.
+// The struct shape of `fn`'s arguments (its fields, their sources and validation rules) is
+// computed once per distinct `fn` type and cached, so repeated calls with handlers of the
+// same shape skip straight to reading the request.
+// A field whose type is itself a struct is bound recursively: an embedded (anonymous) struct's
+// fields are promoted as if they were declared directly on the params struct, while a named
+// struct field's fields are looked up under its name as a prefix (e.g. `pagination.page` for a
+// `Pagination Pagination` field), overridable with `bind:"prefix=..."`. time.Time (RFC3339 by
+// default, overridable with a `format:"..."` tag), net/url.URL and any type implementing
+// encoding.TextUnmarshaler (such as github.com/google/uuid.UUID) are bound as scalars rather
+// than walked as nested structs.
 // See the README for examples.
 func Into(
 	r *http.Request,
 	getURLParam func(key string) string,
 	fn interface{},
-) {
+	opts ...Option,
+) ([]reflect.Value, error) {
+	cfg := newConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	// Input validation
 	fnType := reflect.TypeOf(fn)
-	if fnType.Kind() != reflect.Func {
-		panic("expects a function")
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return nil, fmt.Errorf("%w: expects a function", ErrInvalidHandler)
 	}
 
-	inputs := getInputs(fnType)
-	if inputLen := len(inputs); inputLen != 1 && inputLen != 2 {
-		panic("there should be only one or two arguments")
+	plan, err := getHandlerPlan(fnType)
+	if err != nil {
+		return nil, err
 	}
 
-	input := inputs[0]
-	if input.Kind() != reflect.Struct {
-		panic("argument must be a struct")
-	}
+	// Work //
+	inputValue := reflect.New(plan.inputType).Elem()
 
-	fields := getFields(input)
-	fieldTypes := getStructFieldsTypes(fields)
-	foundComplexTypes := filterComplexTypes(fieldTypes)
-	if len(foundComplexTypes) > 0 {
-		panic("there cannot be any complex types in the first argument's struct")
-	}
+	// Path, query, header and cookie parameters
+	var violations []FieldError
+	for _, fp := range plan.fields {
+		if fp.validation.ignore {
+			continue
+		}
 
-	// Work //
-	inputValue := reflect.New(input).Elem()
+		values := lookupValues(r, getURLParam, fp.source)
+		fieldValue := inputValue.FieldByIndex(fp.index)
+		if err := bindFieldInto(fp, fieldValue, values); err != nil {
+			return nil, &FieldError{Field: fp.field.Name, Err: err}
+		}
 
-	// URLParams
-	for _, field := range fields {
-		urlParam := getURLParam(field.Name)
-		convertToKindAndSetValueIn(urlParam, field.Type.Kind(), inputValue.FieldByName(field.Name))
-	}
+		present := len(values) > 0
+		if fp.validation.required && !present {
+			violations = append(violations, FieldError{Field: fp.field.Name, Err: ErrRequired})
+			continue
+		}
 
-	// Query string
-	for _, field := range fields {
-		var foundValue []string
-		for k, value := range r.URL.Query() {
-			normalizedKey := strings.TrimSuffix(
-				strings.ToLower(k),
-				"[]",
-			)
-
-			if normalizedKey == strings.ToLower(field.Name) {
-				foundValue = value
+		for _, value := range values {
+			if len(fp.validation.enums) > 0 && !containsString(fp.validation.enums, value) {
+				violations = append(violations, FieldError{
+					Field: fp.field.Name,
+					Err:   fmt.Errorf("%w: %q (allowed: %s)", ErrEnum, value, strings.Join(fp.validation.enums, ", ")),
+				})
 				break
 			}
 		}
+	}
 
-		if len(foundValue) > 0 && field.Type.Kind() != reflect.Slice {
-			queryParam := foundValue[0]
-			fieldTypeKind := field.Type.Kind()
-			fieldValue := inputValue.FieldByName(field.Name)
-			convertToKindAndSetValueIn(queryParam, fieldTypeKind, fieldValue)
-		} else if field.Type.Kind() == reflect.Slice {
-			lenValue := len(foundValue)
-			fieldTypeKind := field.Type.Elem().Kind()
-			s := reflect.MakeSlice(field.Type, lenValue, lenValue)
-			for i := 0; i < lenValue; i++ {
-				convertToKindAndSetValueIn(
-					foundValue[i],
-					fieldTypeKind,
-					s.Index(i),
-				)
-			}
-
-			inputValue.FieldByName(field.Name).Set(s)
+	if cfg.validator != nil {
+		if err := cfg.validator.Struct(inputValue.Addr().Interface()); err != nil {
+			violations = append(violations, FieldError{Err: err})
 		}
 	}
 
 	// Request body
 	var complexTypeValue interface{}
-	hasBody := len(inputs) == 2
-	if hasBody {
-		complexType := inputs[1]
-		complexTypeValue = reflect.New(complexType).Interface()
-		err := json.NewDecoder(r.Body).Decode(&complexTypeValue)
-		if err != nil {
-			panic(err)
+	if plan.hasBody {
+		contentType := r.Header.Get("Content-Type")
+		mediaType := parseMediaType(contentType)
+		decoder, ok := cfg.decoders.getByMediaType(mediaType)
+		if !ok {
+			if contentType != "" {
+				return nil, fmt.Errorf("%w: unsupported Content-Type %q", ErrBodyDecode, contentType)
+			}
+			decoder, _ = cfg.decoders.getByMediaType("application/json")
+			mediaType = "application/json"
+		}
+
+		// A WithDecoder override means Into no longer knows how the body maps
+		// onto presence (e.g. a replaced form decoder might not even populate
+		// r.PostForm), so presenceForBody's assumptions about the four
+		// built-in formats don't apply to it.
+		trackPresence := plan.bodyNeedsPresence && !cfg.overriddenContentTypes[mediaType]
+
+		// application/json and application/xml consume r.Body entirely and leave
+		// nothing behind to check presence against afterwards, so buffer it first
+		// if the body type actually has a required/enums tag to check presence for.
+		var raw []byte
+		if trackPresence && (mediaType == "application/json" || mediaType == "application/xml" || mediaType == "text/xml") {
+			var err error
+			raw, err = ioutil.ReadAll(r.Body)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrBodyDecode, err)
+			}
+			r.Body.Close()
+			r.Body = ioutil.NopCloser(bytes.NewReader(raw))
 		}
+
+		complexTypeValue = reflect.New(plan.bodyType).Interface()
+		if err := decoder.Decode(r, complexTypeValue); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBodyDecode, err)
+		}
+
+		var presence map[string]bool
+		var presenceKnown bool
+		if trackPresence {
+			presence, presenceKnown = presenceForBody(r, mediaType, raw)
+		}
+		violations = append(violations, validateBody(complexTypeValue, cfg, presence, presenceKnown, mediaType)...)
+	}
+
+	if len(violations) > 0 {
+		return nil, &ValidationError{Fields: violations}
 	}
 
 	// Call fn
-	if fnValue := reflect.ValueOf(fn); hasBody {
-		fnValue.Call([]reflect.Value{
+	fnValue := reflect.ValueOf(fn)
+	if plan.hasBody {
+		return fnValue.Call([]reflect.Value{
 			inputValue,
 			reflect.Indirect(reflect.ValueOf(complexTypeValue)),
-		})
-	} else {
-		fnValue.Call([]reflect.Value{
-			inputValue,
-		})
+		}), nil
 	}
+
+	return fnValue.Call([]reflect.Value{
+		inputValue,
+	}), nil
 }
 
-func convertToKindAndSetValueIn(valueToSet string, kind reflect.Kind, dstValue reflect.Value) {
-	if valueToSet != "" {
-		switch kind {
-		case reflect.Bool:
-			b, _ := strconv.ParseBool(valueToSet)
-			dstValue.SetBool(b)
-		case reflect.Int:
-			fallthrough
-		case reflect.Int8:
-			fallthrough
-		case reflect.Int16:
-			fallthrough
-		case reflect.Int32:
-			fallthrough
-		case reflect.Int64:
-			fallthrough
-		case reflect.Uint:
-			fallthrough
-		case reflect.Uint8:
-			fallthrough
-		case reflect.Uint16:
-			fallthrough
-		case reflect.Uint32:
-			fallthrough
-		case reflect.Uint64:
-			i, _ := strconv.Atoi(valueToSet)
-			dstValue.SetInt(int64(i))
-		case reflect.Float32:
-			f, _ := strconv.ParseFloat(valueToSet, 32)
-			dstValue.SetFloat(f)
-		case reflect.Float64:
-			f, _ := strconv.ParseFloat(valueToSet, 64)
-			dstValue.SetFloat(f)
-		case reflect.String:
-			dstValue.SetString(valueToSet)
-		default:
-			panic("unsupported field kind " + kind.String())
+func convertToKindAndSetValueIn(valueToSet string, kind reflect.Kind, dstValue reflect.Value) error {
+	if valueToSet == "" {
+		return nil
+	}
+
+	switch kind {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(valueToSet)
+		if err != nil {
+			return &ConversionError{Value: valueToSet, Kind: kind, Err: err}
+		}
+		dstValue.SetBool(b)
+	case reflect.Int:
+		fallthrough
+	case reflect.Int8:
+		fallthrough
+	case reflect.Int16:
+		fallthrough
+	case reflect.Int32:
+		fallthrough
+	case reflect.Int64:
+		fallthrough
+	case reflect.Uint:
+		fallthrough
+	case reflect.Uint8:
+		fallthrough
+	case reflect.Uint16:
+		fallthrough
+	case reflect.Uint32:
+		fallthrough
+	case reflect.Uint64:
+		i, err := strconv.Atoi(valueToSet)
+		if err != nil {
+			return &ConversionError{Value: valueToSet, Kind: kind, Err: err}
+		}
+		dstValue.SetInt(int64(i))
+	case reflect.Float32:
+		f, err := strconv.ParseFloat(valueToSet, 32)
+		if err != nil {
+			return &ConversionError{Value: valueToSet, Kind: kind, Err: err}
 		}
+		dstValue.SetFloat(f)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(valueToSet, 64)
+		if err != nil {
+			return &ConversionError{Value: valueToSet, Kind: kind, Err: err}
+		}
+		dstValue.SetFloat(f)
+	case reflect.String:
+		dstValue.SetString(valueToSet)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedField, kind)
 	}
+
+	return nil
 }
 
 func getFields(input reflect.Type) []reflect.StructField {
@@ -167,16 +241,6 @@ func getFields(input reflect.Type) []reflect.StructField {
 	return fields
 }
 
-func filter(vs []reflect.Type, f func(reflect.Type) bool) []reflect.Type {
-	vsf := []reflect.Type{}
-	for _, v := range vs {
-		if f(v) {
-			vsf = append(vsf, v)
-		}
-	}
-	return vsf
-}
-
 func index(vs []reflect.Kind, t reflect.Kind) int {
 	for i, v := range vs {
 		if v == t {
@@ -190,45 +254,7 @@ func include(vs []reflect.Kind, t reflect.Kind) bool {
 	return index(vs, t) >= 0
 }
 
-func getStructFieldsTypes(fields []reflect.StructField) []reflect.Type {
-	s := []reflect.Type{}
-	for _, f := range fields {
-		s = append(s, f.Type)
-	}
-	return s
-}
-
-func filterComplexTypes(inputs []reflect.Type) []reflect.Type {
-	supportedTypes := []reflect.Kind{
-		reflect.Bool,
-		reflect.Int,
-		reflect.Int8,
-		reflect.Int16,
-		reflect.Int32,
-		reflect.Int64,
-		reflect.Uint,
-		reflect.Uint8,
-		reflect.Uint16,
-		reflect.Uint32,
-		reflect.Uint64,
-		reflect.Float32,
-		reflect.Float64,
-		reflect.Array,
-		reflect.Slice,
-		reflect.String,
-	}
-	foundComplexTypes := filter(inputs, func(input reflect.Type) bool {
-		isSuppportedType := include(supportedTypes, input.Kind())
-		return !isSuppportedType
-	})
-	return foundComplexTypes
-}
-
 func getInputs(fnType reflect.Type) []reflect.Type {
-	if fnType.Kind() != reflect.Func {
-		panic("expects a function")
-	}
-
 	inputs := []reflect.Type{}
 	for i := 0; i < fnType.NumIn(); i++ {
 		inputs = append(inputs, fnType.In(i))
@@ -1,9 +1,17 @@
 package bindparameters
 
 import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
@@ -23,7 +31,10 @@ func bindChiParametersInto(r *http.Request, fn interface{}) (string, string) {
 
 		return ""
 	}
-	returnValues := Into(r, getURLParam, fn)
+	returnValues, err := Into(r, getURLParam, fn)
+	if err != nil {
+		panic(err)
+	}
 	if lenV := len(returnValues); lenV == 0 {
 		return "", ""
 	} else if lenV == 1 {
@@ -186,6 +197,115 @@ func TestQueryStringOfSlices(t *testing.T) {
 		End()
 }
 
+func TestNestedAndEmbeddedStructs(t *testing.T) {
+	router := newApp().Router
+
+	type Pagination struct {
+		Page int `json:"page"`
+		Size int `json:"size"`
+	}
+
+	type BaseParams struct {
+		ID int `json:"id"`
+	}
+
+	router.Get("/user/{id}", func(w http.ResponseWriter, r *http.Request) {
+		bindChiParametersInto(r, func(params struct {
+			BaseParams
+			Pagination Pagination
+		}) {
+			render.JSON(w, r, params)
+		})
+	})
+
+	// GET /user/1234?Pagination.page=2&Pagination.size=50
+	apitest.New().
+		Handler(router).
+		Get("/user/1234").
+		Query("Pagination.page", "2").
+		Query("Pagination.size", "50").
+		Expect(t).
+		Body(`{"id":1234,"Pagination":{"page":2,"size":50}}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+func TestTimeURLAndTextUnmarshalerFields(t *testing.T) {
+	router := newApp().Router
+
+	router.Get("/event/{id}", func(w http.ResponseWriter, r *http.Request) {
+		bindChiParametersInto(r, func(params struct {
+			Starts  time.Time `json:"starts"`
+			Website url.URL   `json:"website"`
+			Tag     hexTag    `json:"tag"`
+		}) {
+			render.JSON(w, r, struct {
+				Starts  string `json:"starts"`
+				Website string `json:"website"`
+				Tag     string `json:"tag"`
+			}{
+				Starts:  params.Starts.Format(time.RFC3339),
+				Website: params.Website.String(),
+				Tag:     string(params.Tag),
+			})
+		})
+	})
+
+	// GET /event/1234?starts=2026-07-27T10:00:00Z&website=https://example.com/path&tag=deadbeef
+	apitest.New().
+		Handler(router).
+		Get("/event/1234").
+		Query("starts", "2026-07-27T10:00:00Z").
+		Query("website", "https://example.com/path").
+		Query("tag", "deadbeef").
+		Expect(t).
+		Body(`{"starts":"2026-07-27T10:00:00Z","website":"https://example.com/path","tag":"deadbeef"}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+// hexTag is a stand-in for the kind of user-defined scalar type (e.g. uuid.UUID)
+// that plugs into Into purely by implementing encoding.TextUnmarshaler.
+type hexTag string
+
+func (t *hexTag) UnmarshalText(text []byte) error {
+	*t = hexTag(text)
+	return nil
+}
+
+func TestSliceOfTextUnmarshalerField(t *testing.T) {
+	router := newApp().Router
+
+	router.Get("/tags/{id}", func(w http.ResponseWriter, r *http.Request) {
+		bindChiParametersInto(r, func(params struct {
+			Tags []upperTag `json:"tags"`
+		}) {
+			render.JSON(w, r, params)
+		})
+	})
+
+	// GET /tags/1234?tags=abc&tags=def
+	apitest.New().
+		Handler(router).
+		Get("/tags/1234").
+		Query("tags", "abc").
+		Query("tags", "def").
+		Expect(t).
+		Body(`{"tags":["ABC","DEF"]}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+// upperTag, unlike hexTag, transforms its input in UnmarshalText, so a test
+// binding a slice of it can tell apart "UnmarshalText ran per element" from
+// convertToKindAndSetValueIn's plain SetString silently bypassing it.
+type upperTag string
+
+func (t *upperTag) UnmarshalText(text []byte) error {
+	*t = upperTag(strings.ToUpper(string(text)))
+	return nil
+}
+
 func TestRequestBody(t *testing.T) {
 	router := newApp().Router
 
@@ -221,6 +341,662 @@ func TestRequestBody(t *testing.T) {
 
 }
 
+func TestXMLRequestBody(t *testing.T) {
+	router := newApp().Router
+
+	type User struct {
+		XMLName xml.Name `xml:"user" json:"-"`
+		Name    string   `xml:"name" json:"name"`
+		Age     int      `xml:"age" json:"age"`
+	}
+
+	router.Post("/user/{id}", func(w http.ResponseWriter, r *http.Request) {
+		bindChiParametersInto(r, func(params struct {
+			ID int `json:"id"`
+		}, user User) {
+			response := struct {
+				ID   int  `json:"id"`
+				User User `json:"user"`
+			}{
+				ID:   params.ID,
+				User: user,
+			}
+			render.JSON(w, r, response)
+		})
+	})
+
+	// POST /user/1234
+	apitest.New().
+		Handler(router).
+		Post("/user/1234").
+		Body(`<user><name>Ronald</name><age>27</age></user>`).
+		ContentType("application/xml").
+		Expect(t).
+		Body(`{"id":1234,"user":{"name":"Ronald","age":27}}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+func TestFormRequestBody(t *testing.T) {
+	router := newApp().Router
+
+	type User struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	router.Post("/user/{id}", func(w http.ResponseWriter, r *http.Request) {
+		bindChiParametersInto(r, func(params struct {
+			ID int `json:"id"`
+		}, user User) {
+			response := struct {
+				ID   int  `json:"id"`
+				User User `json:"user"`
+			}{
+				ID:   params.ID,
+				User: user,
+			}
+			render.JSON(w, r, response)
+		})
+	})
+
+	// POST /user/1234, body name=Ronald&age=27
+	apitest.New().
+		Handler(router).
+		Post("/user/1234").
+		FormData("name", "Ronald").
+		FormData("age", "27").
+		Expect(t).
+		Body(`{"id":1234,"user":{"name":"Ronald","age":27}}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+// TestMultipartRequestBody drives Into with a real multipart request, built by
+// hand since apitest has no multipart helper.
+func TestMultipartRequestBody(t *testing.T) {
+	router := newApp().Router
+
+	type Upload struct {
+		Name string                `json:"name"`
+		File *multipart.FileHeader `json:"-"`
+	}
+
+	router.Post("/upload", func(w http.ResponseWriter, r *http.Request) {
+		bindChiParametersInto(r, func(params struct{}, upload Upload) {
+			render.JSON(w, r, struct {
+				Name     string `json:"name"`
+				Filename string `json:"filename"`
+			}{
+				Name:     upload.Name,
+				Filename: upload.File.Filename,
+			})
+		})
+	})
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	assert.NoError(t, writer.WriteField("name", "Ronald"))
+	part, err := writer.CreateFormFile("file", "avatar.png")
+	assert.NoError(t, err)
+	_, err = part.Write([]byte("fake-image-bytes"))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"name":"Ronald","filename":"avatar.png"}`, rec.Body.String())
+}
+
+// plusOneJSONDecoder is a BodyDecoder used by TestWithDecoderOption to prove
+// WithDecoder actually overrides the registry, rather than just extending it.
+var plusOneJSONDecoder = BodyDecoderFunc(func(r *http.Request, dst interface{}) error {
+	if err := jsonBodyDecode(r, dst); err != nil {
+		return err
+	}
+	dst.(*struct {
+		Age int `json:"age"`
+	}).Age++
+	return nil
+})
+
+func TestWithDecoderOption(t *testing.T) {
+	router := newApp().Router
+
+	router.Post("/user", func(w http.ResponseWriter, r *http.Request) {
+		_, err := Into(r, func(string) string { return "" }, func(params struct{}, user struct {
+			Age int `json:"age"`
+		}) {
+			render.JSON(w, r, user)
+		}, WithDecoder("application/json", plusOneJSONDecoder))
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	apitest.New().
+		Handler(router).
+		Post("/user").
+		JSON(`{"age":27}`).
+		Expect(t).
+		Body(`{"age":28}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+// rawFormDecoder is a BodyDecoder used by
+// TestWithDecoderOverrideSkipsBuiltInPresenceTracking to simulate a form
+// decoder that doesn't go through r.ParseForm, so r.PostForm stays empty.
+var rawFormDecoder = BodyDecoderFunc(func(r *http.Request, dst interface{}) error {
+	raw, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	values, err := url.ParseQuery(string(raw))
+	if err != nil {
+		return err
+	}
+	return bindValuesIntoStruct(values, dst)
+})
+
+// TestWithDecoderOverrideSkipsBuiltInPresenceTracking guards against
+// presenceForBody assuming a built-in format's decoder ran, even when
+// WithDecoder replaced it with one that doesn't populate r.PostForm: a
+// required field that the replacement decoder bound successfully must not be
+// rejected as absent.
+func TestWithDecoderOverrideSkipsBuiltInPresenceTracking(t *testing.T) {
+	router := newApp().Router
+
+	type Order struct {
+		Name string `json:"name" binding:"required"`
+	}
+
+	router.Post("/orders", func(w http.ResponseWriter, r *http.Request) {
+		getURLParam := func(key string) string { return "" }
+
+		_, err := Into(r, getURLParam, func(params struct{}, order Order) {
+			render.JSON(w, r, order)
+		}, WithDecoder("application/x-www-form-urlencoded", rawFormDecoder))
+
+		if validationErr, ok := err.(*ValidationError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, validationErr.Fields)
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		FormData("name", "Ronald").
+		Expect(t).
+		Body(`{"name":"Ronald"}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+func TestBodyValidationTags(t *testing.T) {
+	router := newApp().Router
+
+	type Order struct {
+		Count  int    `json:"count" enums:"1,2,3"`
+		Active bool   `json:"active" binding:"required"`
+		Secret string `json:"secret" binding:"ignore"`
+	}
+
+	router.Post("/orders", func(w http.ResponseWriter, r *http.Request) {
+		getURLParam := func(key string) string { return "" }
+
+		_, err := Into(r, getURLParam, func(params struct{}, order Order) {
+			render.JSON(w, r, order)
+		})
+
+		if validationErr, ok := err.(*ValidationError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, validationErr.Fields)
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	// POST with count explicitly 0 -> present but outside the enum set, violation
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		JSON(`{"count":0,"active":true}`).
+		Expect(t).
+		Status(http.StatusBadRequest).
+		End()
+
+	// POST with active omitted entirely -> required violation, even though Go's
+	// zero value for bool (false) would otherwise be indistinguishable from "sent false"
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		JSON(`{"count":1}`).
+		Expect(t).
+		Status(http.StatusBadRequest).
+		End()
+
+	// POST with active explicitly false -> satisfies required, since it was present;
+	// secret is zeroed out despite being sent, since it's binding:"ignore"
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		JSON(`{"count":1,"active":false,"secret":"leak"}`).
+		Expect(t).
+		Body(`{"count":1,"active":false,"secret":""}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+// TestBodyValidationSkipsEnumForNilPointerField guards against presence
+// tracking misreading an explicit JSON `null` on a pointer field as "sent a
+// value": the key is present in the body, but the field itself is still nil,
+// and should be treated as absent for enums the same way it always has been.
+func TestBodyValidationSkipsEnumForNilPointerField(t *testing.T) {
+	router := newApp().Router
+
+	type Order struct {
+		Status *string `json:"status" enums:"active,inactive"`
+	}
+
+	router.Post("/orders", func(w http.ResponseWriter, r *http.Request) {
+		getURLParam := func(key string) string { return "" }
+
+		_, err := Into(r, getURLParam, func(params struct{}, order Order) {
+			render.JSON(w, r, order)
+		})
+
+		if validationErr, ok := err.(*ValidationError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, validationErr.Fields)
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	// POST with status explicitly null -> nil field, enum check skipped
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		JSON(`{"status":null}`).
+		Expect(t).
+		Body(`{"status":null}` + "\n").
+		Status(http.StatusOK).
+		End()
+
+	// POST with status a valid, non-nil enum value -> compared by the pointed-to
+	// value, not the pointer itself
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		JSON(`{"status":"active"}`).
+		Expect(t).
+		Body(`{"status":"active"}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+// TestFormBodyValidationPresenceMatchesFieldName guards against checkFieldValue
+// looking up presence by a field's `json` tag while bindValuesIntoStruct binds
+// form/multipart bodies by the field's plain Go name: a required field present
+// under its Go name, but absent under its (different) json tag, must still be
+// recognized as sent.
+func TestFormBodyValidationPresenceMatchesFieldName(t *testing.T) {
+	router := newApp().Router
+
+	type Order struct {
+		TotalCount int `json:"count" binding:"required"`
+	}
+
+	router.Post("/orders", func(w http.ResponseWriter, r *http.Request) {
+		getURLParam := func(key string) string { return "" }
+
+		_, err := Into(r, getURLParam, func(params struct{}, order Order) {
+			render.JSON(w, r, order)
+		})
+
+		if validationErr, ok := err.(*ValidationError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, validationErr.Fields)
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	// POST with TotalCount sent under its Go field name, as form decoding binds it
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		FormData("TotalCount", "5").
+		Expect(t).
+		Body(`{"count":5}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+// TestXMLBodyValidationPresenceIncludesAttributes guards against xmlPresence
+// only looking at child elements: a field bound from an XML attribute
+// (`xml:"...,attr"`) must still be recognized as present.
+func TestXMLBodyValidationPresenceIncludesAttributes(t *testing.T) {
+	router := newApp().Router
+
+	type Order struct {
+		XMLName xml.Name `xml:"order" json:"-"`
+		ID      int      `xml:"id,attr" json:"id" binding:"required"`
+	}
+
+	router.Post("/orders", func(w http.ResponseWriter, r *http.Request) {
+		getURLParam := func(key string) string { return "" }
+
+		_, err := Into(r, getURLParam, func(params struct{}, order Order) {
+			render.JSON(w, r, order)
+		})
+
+		if validationErr, ok := err.(*ValidationError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, validationErr.Fields)
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	// POST with id sent as an XML attribute, not a child element
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		Body(`<order id="5"></order>`).
+		ContentType("application/xml").
+		Expect(t).
+		Body(`{"id":5}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+// TestFormBodyValidationIgnoresJSONTagName guards against fieldPresent
+// checking a field's json/xml tag name even for a form/multipart body: a
+// field sent under a key that happens to match its json tag, but not its Go
+// field name (the only name bindValuesIntoStruct actually binds form values
+// by), was never really bound and must still be reported as absent.
+func TestFormBodyValidationIgnoresJSONTagName(t *testing.T) {
+	router := newApp().Router
+
+	type Order struct {
+		TotalCount int `json:"count" binding:"required"`
+	}
+
+	router.Post("/orders", func(w http.ResponseWriter, r *http.Request) {
+		getURLParam := func(key string) string { return "" }
+
+		_, err := Into(r, getURLParam, func(params struct{}, order Order) {
+			render.JSON(w, r, order)
+		})
+
+		if validationErr, ok := err.(*ValidationError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, validationErr.Fields)
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	// POST with a form field named "count" (TotalCount's json tag, not its Go
+	// name): bindValuesIntoStruct never binds it, so TotalCount stays 0 and
+	// required must still fail.
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		FormData("count", "5").
+		Expect(t).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+// TestJSONBodyValidationToleratesTrailingData guards against jsonPresence
+// parsing the buffered body with json.Unmarshal, which (unlike the
+// json.Decoder jsonBodyDecode actually decodes with) rejects any bytes left
+// over after the first JSON value: a body the real decode accepts must still
+// report its fields as present.
+func TestJSONBodyValidationToleratesTrailingData(t *testing.T) {
+	router := newApp().Router
+
+	type Order struct {
+		Name string `json:"name" binding:"required"`
+	}
+
+	router.Post("/orders", func(w http.ResponseWriter, r *http.Request) {
+		getURLParam := func(key string) string { return "" }
+
+		_, err := Into(r, getURLParam, func(params struct{}, order Order) {
+			render.JSON(w, r, order)
+		})
+
+		if validationErr, ok := err.(*ValidationError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, validationErr.Fields)
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	// json.NewDecoder.Decode, used by jsonBodyDecode, only reads the first
+	// value and ignores what follows, so this trailing object is harmless to
+	// the actual decode and must be equally harmless to presence tracking.
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		Body(`{"name":"Ronald"}{"unexpected":true}`).
+		ContentType("application/json").
+		Expect(t).
+		Body(`{"name":"Ronald"}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+// validatorFunc adapts a function to the Validator interface, the same way
+// BodyDecoderFunc adapts one to BodyDecoder.
+type validatorFunc func(v interface{}) error
+
+func (f validatorFunc) Struct(v interface{}) error {
+	return f(v)
+}
+
+func TestWithValidatorOption(t *testing.T) {
+	router := newApp().Router
+
+	validator := WithValidator(validatorFunc(func(v interface{}) error {
+		order, ok := v.(*struct {
+			Count int `json:"count"`
+		})
+		if !ok || order.Count >= 0 {
+			return nil
+		}
+		return fmt.Errorf("count must not be negative")
+	}))
+
+	router.Post("/orders", func(w http.ResponseWriter, r *http.Request) {
+		getURLParam := func(key string) string { return "" }
+
+		_, err := Into(r, getURLParam, func(params struct{}, order struct {
+			Count int `json:"count"`
+		}) {
+			render.JSON(w, r, order)
+		}, validator)
+
+		if validationErr, ok := err.(*ValidationError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, validationErr.Fields)
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	// POST with a negative count -> the external validator rejects it
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		JSON(`{"count":-1}`).
+		Expect(t).
+		Status(http.StatusBadRequest).
+		End()
+
+	// POST with a non-negative count -> passes
+	apitest.New().
+		Handler(router).
+		Post("/orders").
+		JSON(`{"count":5}`).
+		Expect(t).
+		Body(`{"count":5}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+func TestBindTagSourceSelection(t *testing.T) {
+	router := newApp().Router
+
+	router.Get("/user/{id}", func(w http.ResponseWriter, r *http.Request) {
+		bindChiParametersInto(r, func(params struct {
+			ID     int    `bind:"path,name=id" json:"id"`
+			Page   int    `bind:"query,name=page" json:"page"`
+			Auth   string `bind:"header,name=Authorization" json:"auth"`
+			Tenant string `bind:"cookie,name=tenant" json:"tenant"`
+		}) {
+			render.JSON(w, r, params)
+		})
+	})
+
+	apitest.New().
+		Handler(router).
+		Get("/user/1234").
+		Query("page", "2").
+		Header("Authorization", "Bearer token").
+		Cookie("tenant", "acme").
+		Expect(t).
+		Body(`{"id":1234,"page":2,"auth":"Bearer token","tenant":"acme"}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
+func TestRequiredTreatsEmptyValueAsPresentForQueryHeaderAndCookie(t *testing.T) {
+	router := newApp().Router
+
+	router.Get("/user", func(w http.ResponseWriter, r *http.Request) {
+		getURLParam := func(key string) string { return "" }
+
+		_, err := Into(r, getURLParam, func(params struct {
+			Status string `bind:"query,name=status" binding:"required" json:"status"`
+			Auth   string `bind:"header,name=X-Status" binding:"required" json:"auth"`
+			Tenant string `bind:"cookie,name=tenant" binding:"required" json:"tenant"`
+		}) {
+			render.JSON(w, r, params)
+		})
+
+		if validationErr, ok := err.(*ValidationError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, validationErr.Fields)
+		}
+	})
+
+	// all three sent, all empty -> present, so required is satisfied
+	apitest.New().
+		Handler(router).
+		Get("/user").
+		Query("status", "").
+		Header("X-Status", "").
+		Cookie("tenant", "").
+		Expect(t).
+		Body(`{"status":"","auth":"","tenant":""}` + "\n").
+		Status(http.StatusOK).
+		End()
+
+	// none sent -> absent, required violations
+	apitest.New().
+		Handler(router).
+		Get("/user").
+		Expect(t).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func TestValidationTags(t *testing.T) {
+	router := newApp().Router
+
+	router.Get("/user/{id}", func(w http.ResponseWriter, r *http.Request) {
+		getURLParam := func(key string) string {
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				for k := len(rctx.URLParams.Keys) - 1; k >= 0; k-- {
+					if strings.ToLower(rctx.URLParams.Keys[k]) == strings.ToLower(key) {
+						return rctx.URLParams.Values[k]
+					}
+				}
+			}
+			return ""
+		}
+
+		_, err := Into(r, getURLParam, func(params struct {
+			ID     int    `json:"id"`
+			Status string `json:"status" bind:"query,name=status" enums:"active,inactive" binding:"required"`
+		}) {
+			render.JSON(w, r, params)
+		})
+
+		if validationErr, ok := err.(*ValidationError); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, validationErr.Fields)
+		}
+	})
+
+	// GET /user/1234 (status missing -> required violation)
+	apitest.New().
+		Handler(router).
+		Get("/user/1234").
+		Expect(t).
+		Status(http.StatusBadRequest).
+		End()
+
+	// GET /user/1234?status=unknown (status not in enum set -> violation)
+	apitest.New().
+		Handler(router).
+		Get("/user/1234").
+		Query("status", "unknown").
+		Expect(t).
+		Status(http.StatusBadRequest).
+		End()
+
+	// GET /user/1234?status=active
+	apitest.New().
+		Handler(router).
+		Get("/user/1234").
+		Query("status", "active").
+		Expect(t).
+		Body(`{"id":1234,"status":"active"}` + "\n").
+		Status(http.StatusOK).
+		End()
+}
+
 func TestReturnValues(t *testing.T) {
 	router := newApp().Router
 
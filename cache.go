@@ -0,0 +1,99 @@
+package bindparameters
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// fieldPlan is the per-field work Into would otherwise redo on every request:
+// which struct field (possibly nested, hence the FieldByIndex path), where its
+// value comes from, what validation applies to it, and whether it needs a
+// parsing rule other than convertToKindAndSetValueIn.
+type fieldPlan struct {
+	index      []int
+	field      reflect.StructField
+	source     fieldSource
+	validation fieldValidation
+	special    specialKind
+}
+
+// handlerPlan is everything Into can precompute once per handler function type:
+// the shape of its params struct and, if present, its body type.
+type handlerPlan struct {
+	inputType reflect.Type
+	fields    []fieldPlan
+	hasBody   bool
+	bodyType  reflect.Type
+	// bodyNeedsPresence is true when the body type has a `binding:"required"`
+	// or `enums:"..."` tag somewhere, the only validations that need to tell
+	// "absent" apart from "present but zero". It lets Into skip buffering the
+	// request body for presence tracking on the (common) body type that
+	// doesn't use either tag.
+	bodyNeedsPresence bool
+}
+
+// cachedPlan holds either a handlerPlan or the error that was produced trying to
+// build one, so a malformed handler fails the same way on every call instead of
+// re-validating it each time.
+type cachedPlan struct {
+	plan *handlerPlan
+	err  error
+}
+
+// handlerPlanCache caches a *cachedPlan per handler reflect.Type, amortizing the
+// NumIn/NumField walk and the per-field tag parsing Into used to redo on every
+// single request.
+var handlerPlanCache sync.Map // map[reflect.Type]*cachedPlan
+
+// getHandlerPlan returns the handlerPlan for fnType, building and caching it on
+// the first call for that type.
+func getHandlerPlan(fnType reflect.Type) (*handlerPlan, error) {
+	if cached, ok := handlerPlanCache.Load(fnType); ok {
+		c := cached.(*cachedPlan)
+		return c.plan, c.err
+	}
+
+	plan, err := buildHandlerPlan(fnType)
+	actual, _ := handlerPlanCache.LoadOrStore(fnType, &cachedPlan{plan: plan, err: err})
+	c := actual.(*cachedPlan)
+	return c.plan, c.err
+}
+
+func buildHandlerPlan(fnType reflect.Type) (*handlerPlan, error) {
+	inputs := getInputs(fnType)
+	if inputLen := len(inputs); inputLen != 1 && inputLen != 2 {
+		return nil, fmt.Errorf("%w: there should be only one or two arguments", ErrInvalidHandler)
+	}
+
+	inputType := inputs[0]
+	if inputType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: first argument must be a struct", ErrInvalidHandler)
+	}
+
+	fields, err := walkFields(inputType, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &handlerPlan{inputType: inputType, fields: fields}
+	if len(inputs) == 2 {
+		plan.hasBody = true
+		plan.bodyType = inputs[1]
+		plan.bodyNeedsPresence = bodyNeedsPresence(inputs[1])
+	}
+
+	return plan, nil
+}
+
+// bodyNeedsPresence reports whether any field of bodyType carries a
+// `binding:"required"` or `enums:"..."` tag.
+func bodyNeedsPresence(bodyType reflect.Type) bool {
+	for _, field := range getFields(bodyType) {
+		validation := resolveFieldValidation(field)
+		if validation.required || len(validation.enums) > 0 {
+			return true
+		}
+	}
+	return false
+}
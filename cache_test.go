@@ -0,0 +1,28 @@
+package bindparameters
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetHandlerPlanIsCachedPerType(t *testing.T) {
+	fn := func(params struct {
+		ID int `json:"id"`
+	}) {
+	}
+	fnType := reflect.TypeOf(fn)
+
+	plan1, err := getHandlerPlan(fnType)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan2, err := getHandlerPlan(fnType)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if plan1 != plan2 {
+		t.Fatalf("expected the same cached *handlerPlan, got two different instances")
+	}
+}
@@ -0,0 +1,271 @@
+package bindparameters
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// defaultMaxMultipartMemory is the amount of request body kept in memory by
+// http.Request.ParseMultipartForm before the rest is written to temporary files,
+// mirroring the default net/http uses for its own form parsing helpers.
+const defaultMaxMultipartMemory = 32 << 20 // 32 MB
+
+// BodyDecoder decodes the body of r into dst, a pointer to the second argument's type.
+// Implement this interface to plug in a body format Into doesn't support out of the box.
+type BodyDecoder interface {
+	Decode(r *http.Request, dst interface{}) error
+}
+
+// BodyDecoderFunc adapts a function to a BodyDecoder.
+type BodyDecoderFunc func(r *http.Request, dst interface{}) error
+
+// Decode calls f(r, dst).
+func (f BodyDecoderFunc) Decode(r *http.Request, dst interface{}) error {
+	return f(r, dst)
+}
+
+// DecoderRegistry maps request Content-Type values to the BodyDecoder responsible
+// for decoding them. The zero value is not usable; use NewDecoderRegistry.
+type DecoderRegistry struct {
+	decoders map[string]BodyDecoder
+}
+
+// NewDecoderRegistry returns a DecoderRegistry pre-populated with the built-in
+// decoders for application/json, application/xml, application/x-www-form-urlencoded
+// and multipart/form-data.
+func NewDecoderRegistry() *DecoderRegistry {
+	reg := &DecoderRegistry{decoders: map[string]BodyDecoder{}}
+	reg.Register("application/json", BodyDecoderFunc(jsonBodyDecode))
+	reg.Register("application/xml", BodyDecoderFunc(xmlBodyDecode))
+	reg.Register("text/xml", BodyDecoderFunc(xmlBodyDecode))
+	reg.Register("application/x-www-form-urlencoded", BodyDecoderFunc(formBodyDecode))
+	reg.Register("multipart/form-data", BodyDecoderFunc(multipartBodyDecode))
+	return reg
+}
+
+// Register associates contentType with decoder, replacing any decoder previously
+// registered for it. contentType is matched without its parameters, e.g. the
+// "; boundary=..." part of a multipart/form-data header.
+func (reg *DecoderRegistry) Register(contentType string, decoder BodyDecoder) {
+	reg.decoders[contentType] = decoder
+}
+
+// clone returns a shallow copy of reg, so a caller can override a handful of
+// decoders for a single request without mutating the registry others share.
+func (reg *DecoderRegistry) clone() *DecoderRegistry {
+	clone := &DecoderRegistry{decoders: make(map[string]BodyDecoder, len(reg.decoders))}
+	for contentType, decoder := range reg.decoders {
+		clone.decoders[contentType] = decoder
+	}
+	return clone
+}
+
+// Get returns the decoder registered for contentType, if any.
+func (reg *DecoderRegistry) Get(contentType string) (BodyDecoder, bool) {
+	return reg.getByMediaType(parseMediaType(contentType))
+}
+
+// getByMediaType is Get for a contentType that's already been through
+// parseMediaType, sparing a caller that already needs the parsed media type
+// (such as Into, which also uses it to decide whether to buffer the body) a
+// second parse.
+func (reg *DecoderRegistry) getByMediaType(mediaType string) (BodyDecoder, bool) {
+	decoder, ok := reg.decoders[mediaType]
+	return decoder, ok
+}
+
+// parseMediaType strips the parameters (e.g. "; boundary=...") off contentType,
+// falling back to contentType itself if it doesn't parse.
+func parseMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mediaType
+}
+
+// defaultDecoderRegistry is the registry Into uses when no WithDecoder option is given.
+var defaultDecoderRegistry = NewDecoderRegistry()
+
+func jsonBodyDecode(r *http.Request, dst interface{}) error {
+	return json.NewDecoder(r.Body).Decode(dst)
+}
+
+func xmlBodyDecode(r *http.Request, dst interface{}) error {
+	return xml.NewDecoder(r.Body).Decode(dst)
+}
+
+func formBodyDecode(r *http.Request, dst interface{}) error {
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+	return bindValuesIntoStruct(r.PostForm, dst)
+}
+
+func multipartBodyDecode(r *http.Request, dst interface{}) error {
+	if err := r.ParseMultipartForm(defaultMaxMultipartMemory); err != nil {
+		return err
+	}
+	if err := bindValuesIntoStruct(r.MultipartForm.Value, dst); err != nil {
+		return err
+	}
+	return bindFileHeadersIntoStruct(r.MultipartForm.File, dst)
+}
+
+// bindValuesIntoStruct maps url.Values (form fields) onto the exported fields of
+// the struct dst points to, matching on field name case-insensitively, the same
+// way query string parameters are mapped in Into.
+func bindValuesIntoStruct(values url.Values, dst interface{}) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: destination must be a pointer to a struct", ErrBodyDecode)
+	}
+
+	structValue := dstValue.Elem()
+	for _, field := range getFields(structValue.Type()) {
+		raw, ok := firstValueByName(values, field.Name)
+		if !ok {
+			continue
+		}
+
+		if err := convertToKindAndSetValueIn(raw, field.Type.Kind(), structValue.FieldByName(field.Name)); err != nil {
+			return &FieldError{Field: field.Name, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// bindFileHeadersIntoStruct assigns uploaded file parts to *multipart.FileHeader
+// fields of the struct dst points to, matching on field name case-insensitively.
+func bindFileHeadersIntoStruct(files map[string][]*multipart.FileHeader, dst interface{}) error {
+	fileHeaderType := reflect.TypeOf((*multipart.FileHeader)(nil))
+	structValue := reflect.ValueOf(dst).Elem()
+
+	for _, field := range getFields(structValue.Type()) {
+		if field.Type != fileHeaderType {
+			continue
+		}
+
+		for name, headers := range files {
+			if strings.EqualFold(name, field.Name) && len(headers) > 0 {
+				structValue.FieldByName(field.Name).Set(reflect.ValueOf(headers[0]))
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func firstValueByName(values url.Values, name string) (string, bool) {
+	for key, value := range values {
+		if strings.EqualFold(key, name) && len(value) > 0 {
+			return value[0], true
+		}
+	}
+	return "", false
+}
+
+// presenceForBody reports which top-level fields of the request body the
+// client actually supplied, by the name it was sent under, for the four
+// built-in body formats. raw is the buffered body bytes, needed for the
+// application/json and application/xml|text/xml formats since Into has
+// already consumed r.Body through the decoder by the time this runs; the
+// form formats are read back off r.PostForm/r.MultipartForm, which
+// formBodyDecode/multipartBodyDecode leave populated as a side effect.
+// ok is false for any other Content-Type — e.g. one registered through
+// WithDecoder — where Into doesn't know the body's shape, and a caller
+// should fall back to approximating presence from the decoded zero value.
+func presenceForBody(r *http.Request, mediaType string, raw []byte) (presence map[string]bool, ok bool) {
+	switch mediaType {
+	case "application/json":
+		return jsonPresence(raw), true
+	case "application/xml", "text/xml":
+		return xmlPresence(raw), true
+	case "application/x-www-form-urlencoded":
+		return valuesPresence(r.PostForm), true
+	case "multipart/form-data":
+		// Uploaded files only ever bind into *multipart.FileHeader fields
+		// (bindFileHeadersIntoStruct), and a pointer field's own nilness
+		// already tells required/enums apart from "client sent a value"
+		// (checkFieldValue exempts pointer kinds from presence tracking), so
+		// only the non-file form values need to be reported here.
+		if r.MultipartForm == nil {
+			return map[string]bool{}, true
+		}
+		return valuesPresence(r.MultipartForm.Value), true
+	default:
+		return nil, false
+	}
+}
+
+// jsonPresence decodes raw the same way jsonBodyDecode does — a single
+// json.Decoder.Decode, which (unlike json.Unmarshal) only looks at the first
+// JSON value and tolerates trailing bytes after it — so a body that decodes
+// successfully is never reported as empty here just because of trailing data
+// the real decode also ignored.
+func jsonPresence(raw []byte) map[string]bool {
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(raw)).Decode(&fields); err != nil {
+		return map[string]bool{}
+	}
+
+	presence := make(map[string]bool, len(fields))
+	for name := range fields {
+		presence[name] = true
+	}
+	return presence
+}
+
+// xmlElement decodes just enough of an XML document to see which immediate
+// child elements and attributes the root element has, which is all
+// presenceForBody needs — a bound field may be either, via `xml:"name,attr"`.
+type xmlElement struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr   `xml:",any,attr"`
+	Nodes   []xmlElement `xml:",any"`
+}
+
+func xmlPresence(raw []byte) map[string]bool {
+	var root xmlElement
+	if err := xml.Unmarshal(raw, &root); err != nil {
+		return map[string]bool{}
+	}
+
+	presence := make(map[string]bool, len(root.Nodes)+len(root.Attrs))
+	for _, node := range root.Nodes {
+		presence[node.XMLName.Local] = true
+	}
+	for _, attr := range root.Attrs {
+		presence[attr.Name.Local] = true
+	}
+	return presence
+}
+
+func valuesPresence(values url.Values) map[string]bool {
+	presence := make(map[string]bool, len(values))
+	for name := range values {
+		presence[name] = true
+	}
+	return presence
+}
+
+// presentByName reports whether presence contains name, matched case-insensitively
+// the same way firstValueByName and bindValuesIntoStruct match field names.
+func presentByName(presence map[string]bool, name string) bool {
+	for key := range presence {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package bindparameters
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Sentinel errors returned (possibly wrapped) by Into. Use errors.Is to test for them.
+var (
+	// ErrInvalidHandler is returned when fn does not have the shape Into expects:
+	// a function taking one or two arguments, the first of which is a struct.
+	ErrInvalidHandler = errors.New("bindparameters: invalid handler function")
+
+	// ErrUnsupportedField is returned when a field of the params struct has a kind
+	// Into does not know how to bind a value into.
+	ErrUnsupportedField = errors.New("bindparameters: unsupported field kind")
+
+	// ErrBodyDecode is returned when the request body fails to decode into the
+	// second argument of fn.
+	ErrBodyDecode = errors.New("bindparameters: failed to decode request body")
+
+	// ErrConversion is returned when a URL parameter or query string value
+	// cannot be converted to the target field's kind.
+	ErrConversion = errors.New("bindparameters: failed to convert value")
+
+	// ErrRequired is returned, wrapped in a ValidationError, when a field
+	// tagged `binding:"required"` was not provided by the request.
+	ErrRequired = errors.New("bindparameters: field is required")
+
+	// ErrEnum is returned, wrapped in a ValidationError, when a field tagged
+	// `enums:"..."` was set to a value outside of the allowed set.
+	ErrEnum = errors.New("bindparameters: value is not in the allowed set")
+)
+
+// FieldError carries the field name involved in a binding failure, alongside
+// whichever sentinel error it wraps.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s (field %q)", e.Err, e.Field)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ConversionError is returned, wrapped in a FieldError, when a raw string value
+// cannot be converted to the target field's kind.
+type ConversionError struct {
+	Value string
+	Kind  reflect.Kind
+	Err   error
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("%s: cannot convert %q to %s: %v", ErrConversion, e.Value, e.Kind, e.Err)
+}
+
+func (e *ConversionError) Unwrap() error {
+	return ErrConversion
+}
@@ -0,0 +1,191 @@
+package bindparameters
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// scalarKinds are the kinds convertToKindAndSetValueIn (and, for a slice field,
+// its element kind) knows how to parse a string into.
+var scalarKinds = []reflect.Kind{
+	reflect.Bool,
+	reflect.Int,
+	reflect.Int8,
+	reflect.Int16,
+	reflect.Int32,
+	reflect.Int64,
+	reflect.Uint,
+	reflect.Uint8,
+	reflect.Uint16,
+	reflect.Uint32,
+	reflect.Uint64,
+	reflect.Float32,
+	reflect.Float64,
+	reflect.String,
+}
+
+func isScalarKind(kind reflect.Kind) bool {
+	return include(scalarKinds, kind)
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	urlType             = reflect.TypeOf(url.URL{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// specialKind identifies a field that isn't bound through convertToKindAndSetValueIn
+// because its type has its own parsing rule.
+type specialKind int
+
+const (
+	// specialKindNone is an ordinary scalar or slice-of-scalars field.
+	specialKindNone specialKind = iota
+	specialKindTime
+	specialKindURL
+	specialKindTextUnmarshaler
+)
+
+// classifyFieldType reports which, if any, special parsing rule applies to t.
+// uuid.UUID and similar third-party scalar types fall under specialKindTextUnmarshaler,
+// since they implement encoding.TextUnmarshaler on their own.
+func classifyFieldType(t reflect.Type) specialKind {
+	switch {
+	case t == timeType:
+		return specialKindTime
+	case t == urlType:
+		return specialKindURL
+	case reflect.PtrTo(t).Implements(textUnmarshalerType):
+		return specialKindTextUnmarshaler
+	default:
+		return specialKindNone
+	}
+}
+
+// walkFields recursively builds the fieldPlans for structType, a struct that may
+// itself be the params struct or a (possibly embedded) nested struct within it.
+// index is the FieldByIndex path from the root struct down to structType, and
+// namePrefix is prepended to every lookup name resolved below this point, so a
+// `Pagination Pagination` field binds ?pagination.page the same way on every request
+// without structType being walked again.
+func walkFields(structType reflect.Type, index []int, namePrefix string) ([]fieldPlan, error) {
+	var plans []fieldPlan
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+
+		// A slice's special-ness (and, below, its scalar-ness) is decided by its
+		// element type, e.g. []uuid.UUID is a slice of specialKindTextUnmarshaler.
+		elemType := field.Type
+		if elemType.Kind() == reflect.Slice {
+			elemType = elemType.Elem()
+		}
+		special := classifyFieldType(elemType)
+
+		if field.Type.Kind() == reflect.Struct && special == specialKindNone {
+			nestedPrefix := namePrefix
+			if !field.Anonymous {
+				nestedPrefix += resolveNestedPrefix(field)
+			}
+
+			nested, err := walkFields(field.Type, fieldIndex, nestedPrefix)
+			if err != nil {
+				return nil, err
+			}
+			plans = append(plans, nested...)
+			continue
+		}
+
+		if special == specialKindNone && !isScalarKind(elemType.Kind()) {
+			return nil, &FieldError{
+				Field: field.Name,
+				Err:   fmt.Errorf("%w: %s", ErrUnsupportedField, field.Type.Kind()),
+			}
+		}
+
+		src, err := resolveFieldSource(field)
+		if err != nil {
+			return nil, &FieldError{Field: field.Name, Err: err}
+		}
+		src.name = namePrefix + src.name
+
+		plans = append(plans, fieldPlan{
+			index:      fieldIndex,
+			field:      field,
+			source:     src,
+			validation: resolveFieldValidation(field),
+			special:    special,
+		})
+	}
+
+	return plans, nil
+}
+
+// resolveNestedPrefix determines the query/header/cookie key prefix a nested
+// (non-embedded) struct field's own fields are looked up under: the field's
+// `json` name (or Go name) followed by ".", unless overridden by `bind:"prefix=..."`.
+func resolveNestedPrefix(field reflect.StructField) string {
+	name := field.Name
+	if jsonName := jsonFieldName(field); jsonName != "" {
+		name = jsonName
+	}
+	prefix := name + "."
+
+	tag, ok := field.Tag.Lookup("bind")
+	if !ok {
+		return prefix
+	}
+
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if p := strings.TrimPrefix(part, "prefix="); p != part {
+			return p
+		}
+	}
+
+	return prefix
+}
+
+// parseTimeInto parses raw as a time.Time using field's `format:"..."` tag, or
+// time.RFC3339 if it has none, and sets it into fieldValue.
+func parseTimeInto(raw string, field reflect.StructField, fieldValue reflect.Value) error {
+	format := field.Tag.Get("format")
+	if format == "" {
+		format = time.RFC3339
+	}
+
+	t, err := time.Parse(format, raw)
+	if err != nil {
+		return &ConversionError{Value: raw, Kind: reflect.Struct, Err: err}
+	}
+
+	fieldValue.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// parseURLInto parses raw as a url.URL and sets it into fieldValue.
+func parseURLInto(raw string, fieldValue reflect.Value) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return &ConversionError{Value: raw, Kind: reflect.Struct, Err: err}
+	}
+
+	fieldValue.Set(reflect.ValueOf(*u))
+	return nil
+}
+
+// parseTextUnmarshalerInto calls UnmarshalText([]byte(raw)) on fieldValue,
+// which must be addressable and implement encoding.TextUnmarshaler on its
+// pointer receiver (this is how types such as uuid.UUID plug into Into).
+func parseTextUnmarshalerInto(raw string, fieldValue reflect.Value) error {
+	unmarshaler := fieldValue.Addr().Interface().(encoding.TextUnmarshaler)
+	if err := unmarshaler.UnmarshalText([]byte(raw)); err != nil {
+		return &ConversionError{Value: raw, Kind: fieldValue.Kind(), Err: err}
+	}
+	return nil
+}
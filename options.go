@@ -0,0 +1,44 @@
+package bindparameters
+
+// config holds the settings an Option can customize for a single Into call.
+type config struct {
+	decoders  *DecoderRegistry
+	validator Validator
+	// overriddenContentTypes tracks every contentType passed to WithDecoder, by
+	// its parsed media type, so Into knows presenceForBody's assumptions about
+	// how the four built-in formats report presence (e.g. reading back
+	// r.PostForm) no longer hold once that format's decoder has been replaced.
+	overriddenContentTypes map[string]bool
+}
+
+func newConfig() *config {
+	return &config{decoders: defaultDecoderRegistry}
+}
+
+// Option customizes the behavior of a single Into call.
+type Option func(*config)
+
+// WithDecoder overrides (or adds) the BodyDecoder used for requests whose
+// Content-Type matches contentType, without affecting other Into calls.
+func WithDecoder(contentType string, decoder BodyDecoder) Option {
+	return func(c *config) {
+		if c.decoders == defaultDecoderRegistry {
+			c.decoders = defaultDecoderRegistry.clone()
+		}
+		c.decoders.Register(contentType, decoder)
+
+		if c.overriddenContentTypes == nil {
+			c.overriddenContentTypes = map[string]bool{}
+		}
+		c.overriddenContentTypes[parseMediaType(contentType)] = true
+	}
+}
+
+// WithValidator runs v.Struct against the params struct and, if present, the
+// decoded body, in addition to any `binding`/`enums` tags. A non-nil error it
+// returns is added as a violation of the ValidationError Into returns.
+func WithValidator(v Validator) Option {
+	return func(c *config) {
+		c.validator = v
+	}
+}
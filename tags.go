@@ -0,0 +1,175 @@
+package bindparameters
+
+import (
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"strings"
+)
+
+// fieldSource describes where a single field of the params struct should be read
+// from, and under what name, as resolved from its `bind` and `json` struct tags.
+type fieldSource struct {
+	// from is one of "auto", "path", "query", "header" or "cookie".
+	// "auto" preserves the pre-`bind`-tag behavior: try the URL param first,
+	// then let a matching query string parameter override it.
+	from string
+	name string
+}
+
+// resolveFieldSource determines how field should be bound, honoring an explicit
+// `bind:"path|query|header|cookie,name=..."` tag. In its absence, the field's
+// `json:"..."` name (if any) is used for lookups and it is bound the historical
+// way: from the URL param, then overridden by a same-named query string value.
+func resolveFieldSource(field reflect.StructField) (fieldSource, error) {
+	name := field.Name
+	if jsonName := jsonFieldName(field); jsonName != "" {
+		name = jsonName
+	}
+
+	tag, ok := field.Tag.Lookup("bind")
+	if !ok {
+		return fieldSource{from: "auto", name: name}, nil
+	}
+
+	parts := strings.Split(tag, ",")
+	from := strings.TrimSpace(parts[0])
+	for _, part := range parts[1:] {
+		if renamed := strings.TrimPrefix(strings.TrimSpace(part), "name="); renamed != part {
+			name = renamed
+		}
+	}
+
+	switch from {
+	case "path", "query", "header", "cookie":
+		return fieldSource{from: from, name: name}, nil
+	default:
+		return fieldSource{}, fmt.Errorf("%w: unknown bind source %q", ErrInvalidHandler, from)
+	}
+}
+
+// lookupValues returns the raw string value(s) found for src in r (and, for a
+// "path" source, via getURLParam), or nil if none were found. Presence means
+// the key/header/cookie was sent at all, not that its value is non-empty, so
+// e.g. a `?status=` or an empty `X-Status` header counts as present for
+// `binding:"required"`. The one exception is "path": getURLParam has no way
+// to report "sent empty" separately from "absent", so an empty URL param is
+// treated as absent, as it always has been. For "auto", a matching query
+// string value overrides a same-named URL param, preserving the historical
+// precedence.
+func lookupValues(r *http.Request, getURLParam func(key string) string, src fieldSource) []string {
+	switch src.from {
+	case "path":
+		if value := getURLParam(src.name); value != "" {
+			return []string{value}
+		}
+		return nil
+	case "header":
+		if values, ok := r.Header[textproto.CanonicalMIMEHeaderKey(src.name)]; ok {
+			return values
+		}
+		return nil
+	case "cookie":
+		if cookie, err := r.Cookie(src.name); err == nil {
+			return []string{cookie.Value}
+		}
+		return nil
+	case "query":
+		return queryValues(r, src.name)
+	default: // "auto"
+		if values := queryValues(r, src.name); len(values) > 0 {
+			return values
+		}
+		if value := getURLParam(src.name); value != "" {
+			return []string{value}
+		}
+		return nil
+	}
+}
+
+// queryValues returns every query string value for name, matched
+// case-insensitively and ignoring a trailing "[]".
+func queryValues(r *http.Request, name string) []string {
+	for k, value := range r.URL.Query() {
+		normalizedKey := strings.TrimSuffix(strings.ToLower(k), "[]")
+		if normalizedKey == strings.ToLower(name) {
+			return value
+		}
+	}
+	return nil
+}
+
+// bindFieldInto converts values into fieldValue according to fp. A slice field
+// collects every value (becoming an empty, non-nil slice when there are none),
+// each element bound the same way a scalar field of the slice's element type
+// would be; any other kind takes the first value, leaving the field untouched
+// if values is empty. time.Time, url.URL and encoding.TextUnmarshaler
+// implementations (fp.special) are parsed by their own rule instead of
+// convertToKindAndSetValueIn, whether the field is scalar or a slice of them.
+func bindFieldInto(fp fieldPlan, fieldValue reflect.Value, values []string) error {
+	if len(values) == 0 && fp.field.Type.Kind() != reflect.Slice {
+		return nil
+	}
+
+	if fp.field.Type.Kind() != reflect.Slice {
+		return bindScalarInto(fp, fieldValue, values[0])
+	}
+
+	s := reflect.MakeSlice(fp.field.Type, len(values), len(values))
+	for i, value := range values {
+		if err := bindScalarInto(fp, s.Index(i), value); err != nil {
+			return err
+		}
+	}
+
+	fieldValue.Set(s)
+	return nil
+}
+
+// bindScalarInto binds a single raw value into fieldValue, which is either the
+// field itself (non-slice fp) or one element of it (slice fp).
+func bindScalarInto(fp fieldPlan, fieldValue reflect.Value, value string) error {
+	switch fp.special {
+	case specialKindTime:
+		return parseTimeInto(value, fp.field, fieldValue)
+	case specialKindURL:
+		return parseURLInto(value, fieldValue)
+	case specialKindTextUnmarshaler:
+		return parseTextUnmarshalerInto(value, fieldValue)
+	}
+
+	return convertToKindAndSetValueIn(value, fieldValue.Kind(), fieldValue)
+}
+
+// jsonFieldName returns the name a field's `json` tag requests, or "" if the
+// field has no `json` tag, is anonymous (`json:"-"`) or doesn't rename the field.
+func jsonFieldName(field reflect.StructField) string {
+	jsonTag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return ""
+	}
+
+	name := strings.Split(jsonTag, ",")[0]
+	if name == "" || name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+// xmlFieldName returns the name an `xml` struct tag requests, or "" if the
+// field has no `xml` tag, is anonymous (`xml:"-"`) or doesn't rename the field.
+func xmlFieldName(field reflect.StructField) string {
+	xmlTag, ok := field.Tag.Lookup("xml")
+	if !ok {
+		return ""
+	}
+
+	name := strings.Split(xmlTag, ",")[0]
+	if name == "" || name == "-" {
+		return ""
+	}
+
+	return name
+}
@@ -0,0 +1,163 @@
+package bindparameters
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Validator lets a caller plug in an external validation library (such as
+// go-playground/validator) to run in addition to the `binding`/`enums` tags
+// Into already understands. Struct is called once for the params struct and,
+// if present, once more for the decoded body.
+type Validator interface {
+	Struct(v interface{}) error
+}
+
+// ValidationError is returned by Into when one or more fields of the params
+// struct and/or the decoded body fail a `binding:"required"`, `enums:"..."`
+// or external Validator check. Unlike a binding error, every violation is
+// collected before Into gives up, so a caller can report them all at once.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+	return fmt.Sprintf("bindparameters: validation failed: %s", strings.Join(messages, "; "))
+}
+
+// fieldValidation is what a field's `binding` and `enums` tags request.
+type fieldValidation struct {
+	required bool
+	ignore   bool
+	enums    []string
+}
+
+// resolveFieldValidation reads field's `binding:"required|ignore"` and
+// `enums:"a,b,c"` tags. A field without either tag has no validation rules.
+func resolveFieldValidation(field reflect.StructField) fieldValidation {
+	var validation fieldValidation
+
+	if tag, ok := field.Tag.Lookup("binding"); ok {
+		for _, rule := range strings.Split(tag, ",") {
+			switch strings.TrimSpace(rule) {
+			case "required":
+				validation.required = true
+			case "ignore":
+				validation.ignore = true
+			}
+		}
+	}
+
+	if tag, ok := field.Tag.Lookup("enums"); ok && tag != "" {
+		for _, value := range strings.Split(tag, ",") {
+			validation.enums = append(validation.enums, strings.TrimSpace(value))
+		}
+	}
+
+	return validation
+}
+
+// validateBody applies `binding`/`enums` tags and, if cfg supplies one, an
+// external Validator to the already-decoded body value. Fields tagged
+// `binding:"ignore"` are zeroed out first, so server-generated values a
+// client sent can't sneak through. presence reports, by the name each field
+// was sent under, which top-level fields the client actually supplied;
+// presenceKnown is false for a Content-Type Into doesn't know the shape of
+// (one registered through WithDecoder), in which case presence is
+// approximated from the decoded value's zero-ness instead, as before.
+// mediaType says which naming convention presence's keys follow (meaningless,
+// and unused, when presenceKnown is false).
+func validateBody(bodyPtr interface{}, cfg *config, presence map[string]bool, presenceKnown bool, mediaType string) []FieldError {
+	var violations []FieldError
+
+	structValue := reflect.ValueOf(bodyPtr).Elem()
+	for _, field := range getFields(structValue.Type()) {
+		validation := resolveFieldValidation(field)
+		fieldValue := structValue.FieldByName(field.Name)
+
+		if validation.ignore {
+			fieldValue.Set(reflect.Zero(field.Type))
+			continue
+		}
+
+		violations = append(violations, checkFieldValue(field, fieldValue, validation, presence, presenceKnown, mediaType)...)
+	}
+
+	if cfg.validator != nil {
+		if err := cfg.validator.Struct(bodyPtr); err != nil {
+			violations = append(violations, FieldError{Err: err})
+		}
+	}
+
+	return violations
+}
+
+// fieldPresent reports whether field was sent, under the one name the decoder
+// for mediaType would have keyed it by: its `json` tag for application/json,
+// its `xml` tag for application/xml/text/xml, or its plain Go field name for
+// application/x-www-form-urlencoded and multipart/form-data (bindValuesIntoStruct
+// matches form values by field name, not `json` tag). Checking every
+// convention regardless of mediaType would wrongly count a field as present
+// when its json/xml tag happens to collide with an unrelated form field the
+// client actually sent.
+func fieldPresent(field reflect.StructField, presence map[string]bool, mediaType string) bool {
+	switch mediaType {
+	case "application/xml", "text/xml":
+		if xmlName := xmlFieldName(field); xmlName != "" {
+			return presentByName(presence, xmlName)
+		}
+		return presentByName(presence, field.Name)
+	case "application/x-www-form-urlencoded", "multipart/form-data":
+		return presentByName(presence, field.Name)
+	default: // application/json
+		if jsonName := jsonFieldName(field); jsonName != "" {
+			return presentByName(presence, jsonName)
+		}
+		return presentByName(presence, field.Name)
+	}
+}
+
+func checkFieldValue(field reflect.StructField, fieldValue reflect.Value, validation fieldValidation, presence map[string]bool, presenceKnown bool, mediaType string) []FieldError {
+	var violations []FieldError
+
+	present := !fieldValue.IsZero()
+	// A pointer field already encodes "the client left this unset" as nil, so
+	// it's exempt from presence tracking: an explicit JSON `null` shouldn't be
+	// treated as "present" and run through enum/required as if it were a value.
+	if presenceKnown && fieldValue.Kind() != reflect.Ptr {
+		present = fieldPresent(field, presence, mediaType)
+	}
+
+	if validation.required && !present {
+		violations = append(violations, FieldError{Field: field.Name, Err: ErrRequired})
+		return violations
+	}
+
+	if len(validation.enums) == 0 || !present {
+		return violations
+	}
+
+	raw := fmt.Sprintf("%v", reflect.Indirect(fieldValue).Interface())
+	if !containsString(validation.enums, raw) {
+		violations = append(violations, FieldError{
+			Field: field.Name,
+			Err:   fmt.Errorf("%w: %q (allowed: %s)", ErrEnum, raw, strings.Join(validation.enums, ", ")),
+		})
+	}
+
+	return violations
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, candidate := range haystack {
+		if candidate == needle {
+			return true
+		}
+	}
+	return false
+}